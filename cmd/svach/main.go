@@ -14,11 +14,16 @@ import (
 )
 
 var (
-	recursive bool
-	change    bool
-	name      bool
-	rootDir   string
-	treeMap   map[string]treeprint.Tree
+	recursive      bool
+	change         bool
+	name           bool
+	nativePatterns bool
+	rootDir        string
+	treeMap        map[string]treeprint.Tree
+	sessions       map[string]*svach.Session
+
+	includes patternList
+	excludes patternList
 )
 
 var separator = string(filepath.Separator)
@@ -26,8 +31,11 @@ var arrow = "━━▶ "
 
 func init() {
 	flag.BoolVar(&name, "n", false, "name")
-	flag.BoolVar(&recursive, "r", false, "recursive")
+	flag.BoolVar(&recursive, "r", false, "recursive, sanitizes the full path when given args")
 	flag.BoolVar(&change, "c", false, "change fileNames, used in conjunction")
+	flag.Var(&includes, "include", "only walk into/print paths matching this pattern, repeatable")
+	flag.Var(&excludes, "exclude", "prune paths matching this pattern, repeatable")
+	flag.BoolVar(&nativePatterns, "native-patterns", false, "-include/-exclude patterns use the OS-native separator instead of '/'")
 }
 
 var s *svach.Svach
@@ -45,9 +53,14 @@ func main() {
 
 			var nn string
 
-			if !name {
+			switch {
+			case recursive && !name:
+				nn = s.PathClean(n)
+			case recursive:
+				nn = s.Path(n)
+			case !name:
 				nn = s.Clean(n)
-			} else {
+			default:
 				nn = s.Name(n)
 			}
 
@@ -63,6 +76,8 @@ func main() {
 		treeMap = make(map[string]treeprint.Tree)
 		treeMap[rootDir] = treeprint.New()
 
+		sessions = make(map[string]*svach.Session)
+
 		filepath.Walk(rootDir, rename)
 
 		fmt.Print(treeMap[rootDir].String())
@@ -88,10 +103,43 @@ func rename(old string, info os.FileInfo, err error) error {
 	}
 
 	if file != "" {
+		rel := relPath(old)
+
+		if len(excludes) > 0 {
+			res := matchAny(excludes, rel)
+			if res.matched {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if res.partial && info.IsDir() {
+				// rel is itself a prefix of an exclude pattern (e.g.
+				// "node_modules" under "-exclude node_modules/*"):
+				// prune the whole subtree instead of walking it one
+				// file at a time only to exclude each individually.
+				return filepath.SkipDir
+			}
+		}
+
+		if len(includes) > 0 {
+			res := matchAny(includes, rel)
+			if !res.matched {
+				if info.IsDir() && !res.partial {
+					return filepath.SkipDir
+				}
+				// A directory that's only a partial prefix is descended
+				// into, but not itself renamed or printed.
+				return nil
+			}
+		}
+
+		sess := sessionFor(dir)
+
 		if !name {
-			new = s.Clean(file)
+			new = sess.UniqueClean(file)
 		} else {
-			new = s.Name(file)
+			new = sess.UniqueName(file)
 		}
 
 		if file != new {
@@ -124,6 +172,19 @@ func rename(old string, info os.FileInfo, err error) error {
 	return nil
 }
 
+// sessionFor returns the Session tracking names already renamed into
+// dir, creating one the first time dir is seen, so sanitized names
+// that collide within a directory get a unique suffix instead of
+// clobbering each other.
+func sessionFor(dir string) *svach.Session {
+	sess, ok := sessions[dir]
+	if !ok {
+		sess = s.NewSession()
+		sessions[dir] = sess
+	}
+	return sess
+}
+
 func createLink(dir string) {
 	roots := strings.SplitAfter(rootDir, separator)
 	dirs := strings.SplitAfter(dir, separator)
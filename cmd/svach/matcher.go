@@ -0,0 +1,102 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// patternList collects repeated -include/-exclude flag values.
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *patternList) Set(v string) error {
+	*p = append(*p, v)
+	return nil
+}
+
+// matchResult is the outcome of matching a path against a set of
+// patterns: whether it fully matched one, and whether it's a partial
+// prefix of one - meaning traversal should keep descending.
+type matchResult struct {
+	matched bool
+	partial bool
+}
+
+// matchPattern reports whether pattern matches path, and if it
+// doesn't, whether path is a partial prefix of pattern: path has
+// fewer separators than pattern, but the components they share in
+// common already match, so a directory at path may still contain
+// files pattern would match further down.
+func matchPattern(pattern, path string) (matched, partial bool, err error) {
+	patComps := splitClean(pattern)
+	pathComps := splitClean(path)
+
+	n := len(pathComps)
+	if n > len(patComps) {
+		n = len(patComps)
+	}
+
+	trimmedPattern := strings.Join(patComps[:n], "/")
+	trimmedPath := strings.Join(pathComps[:n], "/")
+
+	matched, err = filepath.Match(trimmedPattern, trimmedPath)
+	if err != nil {
+		return false, false, err
+	}
+
+	if matched && len(pathComps) < len(patComps) {
+		return false, true, nil
+	}
+
+	return matched, false, nil
+}
+
+// splitClean splits a slash-delimited path into its non-empty
+// components.
+func splitClean(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// matchAny matches path against every pattern in patterns, converting
+// each pattern from OS-native to slash-delimited first when
+// nativePatterns is set, and aggregates the results.
+func matchAny(patterns []string, path string) matchResult {
+	var res matchResult
+
+	for _, pattern := range patterns {
+		if nativePatterns {
+			pattern = filepath.ToSlash(pattern)
+		}
+
+		matched, partial, err := matchPattern(pattern, path)
+		if err != nil {
+			continue
+		}
+
+		if matched {
+			res.matched = true
+		}
+		if partial {
+			res.partial = true
+		}
+	}
+
+	return res
+}
+
+// relPath returns old's path relative to rootDir, slash-delimited, for
+// matching against -include/-exclude patterns.
+func relPath(old string) string {
+	rel, err := filepath.Rel(rootDir, old)
+	if err != nil {
+		rel = old
+	}
+	return filepath.ToSlash(rel)
+}
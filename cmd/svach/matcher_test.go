@@ -0,0 +1,121 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitClean(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"/a/b/c/", []string{"a", "b", "c"}},
+		{"a/b/c", []string{"a", "b", "c"}},
+		{"", nil},
+		{"/", nil},
+	}
+
+	for _, c := range cases {
+		got := splitClean(c.in)
+		if len(got) != len(c.want) {
+			t.Errorf("splitClean(%q) = %v, want %v", c.in, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("splitClean(%q) = %v, want %v", c.in, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMatchPatternExact(t *testing.T) {
+	matched, partial, err := matchPattern("a/b", "a/b")
+	if err != nil {
+		t.Fatalf("matchPattern returned error: %v", err)
+	}
+	if !matched || partial {
+		t.Errorf("matchPattern(%q, %q) = (%v, %v), want (true, false)", "a/b", "a/b", matched, partial)
+	}
+}
+
+func TestMatchPatternPartialPrefix(t *testing.T) {
+	// "src" is a prefix of pattern "src/*" - traversal must keep
+	// descending into it even though it doesn't fully match yet.
+	matched, partial, err := matchPattern("src/*", "src")
+	if err != nil {
+		t.Fatalf("matchPattern returned error: %v", err)
+	}
+	if matched || !partial {
+		t.Errorf("matchPattern(%q, %q) = (%v, %v), want (false, true)", "src/*", "src", matched, partial)
+	}
+}
+
+func TestMatchPatternMatchesSeveralLevelsDown(t *testing.T) {
+	// "src/*" matches anything under "src/<anything>", including paths
+	// several levels below the single wildcard component.
+	matched, partial, err := matchPattern("src/*", "src/a/b/c")
+	if err != nil {
+		t.Fatalf("matchPattern returned error: %v", err)
+	}
+	if !matched || partial {
+		t.Errorf("matchPattern(%q, %q) = (%v, %v), want (true, false)", "src/*", "src/a/b/c", matched, partial)
+	}
+}
+
+func TestMatchPatternNoMatch(t *testing.T) {
+	matched, partial, err := matchPattern("src/*", "pkg/a")
+	if err != nil {
+		t.Fatalf("matchPattern returned error: %v", err)
+	}
+	if matched || partial {
+		t.Errorf("matchPattern(%q, %q) = (%v, %v), want (false, false)", "src/*", "pkg/a", matched, partial)
+	}
+}
+
+func TestMatchAnyAggregatesResults(t *testing.T) {
+	old := nativePatterns
+	nativePatterns = false
+	defer func() { nativePatterns = old }()
+
+	res := matchAny([]string{"pkg/*", "src/*"}, "src/a/b")
+	if !res.matched {
+		t.Errorf("matchAny(...) matched = false, want true")
+	}
+	if res.partial {
+		t.Errorf("matchAny(...) partial = true, want false")
+	}
+
+	res = matchAny([]string{"src/sub/*"}, "src")
+	if res.matched {
+		t.Errorf("matchAny(...) matched = true, want false")
+	}
+	if !res.partial {
+		t.Errorf("matchAny(...) partial = false, want true")
+	}
+}
+
+func TestMatchAnyNativePatterns(t *testing.T) {
+	old := nativePatterns
+	nativePatterns = true
+	defer func() { nativePatterns = old }()
+
+	native := "src" + string(filepath.Separator) + "*"
+	res := matchAny([]string{native}, "src/a")
+	if !res.matched {
+		t.Errorf("matchAny with native pattern %q against %q matched = false, want true", native, "src/a")
+	}
+}
+
+func TestRelPath(t *testing.T) {
+	oldRoot := rootDir
+	rootDir = filepath.FromSlash("/tmp/root/")
+	defer func() { rootDir = oldRoot }()
+
+	got := relPath(filepath.FromSlash("/tmp/root/a/b.txt"))
+	if got != "a/b.txt" {
+		t.Errorf("relPath(...) = %q, want %q", got, "a/b.txt")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xlab/treeprint"
+
+	svach "github.com/nilsocket/svach"
+)
+
+// fakeDirInfo is a minimal os.FileInfo for a directory, for driving
+// rename without a real filesystem walk.
+type fakeDirInfo struct{ name string }
+
+func (f fakeDirInfo) Name() string       { return f.name }
+func (f fakeDirInfo) Size() int64        { return 0 }
+func (f fakeDirInfo) Mode() os.FileMode  { return os.ModeDir }
+func (f fakeDirInfo) ModTime() time.Time { return time.Time{} }
+func (f fakeDirInfo) IsDir() bool        { return true }
+func (f fakeDirInfo) Sys() interface{}   { return nil }
+
+func TestRenameSkipsDirThatPartiallyMatchesExclude(t *testing.T) {
+	oldRoot, oldExcludes, oldIncludes, oldRecursive, oldSessions, oldTreeMap, oldS :=
+		rootDir, excludes, includes, recursive, sessions, treeMap, s
+	defer func() {
+		rootDir, excludes, includes, recursive, sessions, treeMap, s =
+			oldRoot, oldExcludes, oldIncludes, oldRecursive, oldSessions, oldTreeMap, oldS
+	}()
+
+	rootDir = "/tmp/testroot" + separator
+	excludes = patternList{"node_modules/*"}
+	includes = nil
+	recursive = false
+	sessions = make(map[string]*svach.Session)
+	treeMap = map[string]treeprint.Tree{rootDir: treeprint.New()}
+	s = svach.New()
+
+	old := rootDir + "node_modules"
+	err := rename(old, fakeDirInfo{name: "node_modules"}, nil)
+	if err != filepath.SkipDir {
+		t.Errorf("rename(...) = %v, want filepath.SkipDir", err)
+	}
+}
+
+func TestRenameStillExcludesFullyMatchedFile(t *testing.T) {
+	oldRoot, oldExcludes, oldIncludes, oldRecursive, oldSessions, oldTreeMap, oldS :=
+		rootDir, excludes, includes, recursive, sessions, treeMap, s
+	defer func() {
+		rootDir, excludes, includes, recursive, sessions, treeMap, s =
+			oldRoot, oldExcludes, oldIncludes, oldRecursive, oldSessions, oldTreeMap, oldS
+	}()
+
+	rootDir = "/tmp/testroot" + separator
+	excludes = patternList{"secret.txt"}
+	includes = nil
+	recursive = false
+	sessions = make(map[string]*svach.Session)
+	treeMap = map[string]treeprint.Tree{rootDir: treeprint.New()}
+	s = svach.New()
+
+	old := rootDir + "secret.txt"
+	err := rename(old, fakeFileInfo{name: "secret.txt"}, nil)
+	if err != nil {
+		t.Errorf("rename(...) = %v, want nil (excluded file is skipped, not an error)", err)
+	}
+}
+
+// fakeFileInfo is a minimal os.FileInfo for a regular file.
+type fakeFileInfo struct{ name string }
+
+func (f fakeFileInfo) Name() string       { return f.name }
+func (f fakeFileInfo) Size() int64        { return 0 }
+func (f fakeFileInfo) Mode() os.FileMode  { return 0 }
+func (f fakeFileInfo) ModTime() time.Time { return time.Time{} }
+func (f fakeFileInfo) IsDir() bool        { return false }
+func (f fakeFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,37 @@
+package svach
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+)
+
+// FallbackFunc computes the name returned in place of original when
+// sanitizing it produces a name that's empty or still reserved.
+type FallbackFunc func(original string) string
+
+// MD5Fallback is the default FallbackFunc; it returns the hex md5 sum
+// of original.
+func MD5Fallback(original string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(original)))
+}
+
+// SHA256Fallback returns the first 16 hex characters of original's
+// sha256 sum.
+func SHA256Fallback(original string) string {
+	sum := sha256.Sum256([]byte(original))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+// WithFallback overrides the FallbackFunc a Svach created via
+// WithProfile uses. The default is MD5Fallback.
+func WithFallback(fn FallbackFunc) Option {
+	return func(s *Svach) {
+		s.fallback = fn
+	}
+}
+
+// SetFallback overrides s's FallbackFunc. The default is MD5Fallback.
+func (s *Svach) SetFallback(fn FallbackFunc) {
+	s.fallback = fn
+}
@@ -0,0 +1,44 @@
+package svach_test
+
+import (
+	"testing"
+
+	"github.com/nilsocket/svach"
+)
+
+func TestMD5Fallback(t *testing.T) {
+	got := svach.MD5Fallback("hello")
+	want := "5d41402abc4b2a76b9719d911017c592"
+	if got != want {
+		t.Errorf("MD5Fallback(%q) = %q, want %q", "hello", got, want)
+	}
+}
+
+func TestSHA256Fallback(t *testing.T) {
+	got := svach.SHA256Fallback("hello")
+	want := "2cf24dba5fb0a30e"
+	if got != want {
+		t.Errorf("SHA256Fallback(%q) = %q, want %q", "hello", got, want)
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	s := svach.WithProfile(svach.ProfileWindows, svach.WithFallback(svach.SHA256Fallback))
+
+	got := s.Name("CON")
+	want := svach.SHA256Fallback("CON")
+	if got != want {
+		t.Errorf("Name(%q) = %q, want %q (SHA256Fallback)", "CON", got, want)
+	}
+}
+
+func TestSetFallback(t *testing.T) {
+	s := svach.New()
+	s.SetFallback(svach.SHA256Fallback)
+
+	got := s.Name("CON")
+	want := svach.SHA256Fallback("CON")
+	if got != want {
+		t.Errorf("Name(%q) = %q, want %q (SHA256Fallback)", "CON", got, want)
+	}
+}
@@ -0,0 +1,224 @@
+// Package fs adapts svach to the afero.Fs and io/fs.FS filesystem
+// interfaces, so applications already built on one of them can drop
+// svach in as middleware instead of sprinkling svach.Name calls at
+// every write site.
+package fs
+
+import (
+	stdfs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	svach "github.com/nilsocket/svach"
+)
+
+// RewriteFunc is called with the original and sanitized form of a path
+// whenever Wrap rewrites one, for logging or auditing.
+type RewriteFunc func(orig, sanitized string)
+
+// Option configures an Fs returned by Wrap.
+type Option func(*Fs)
+
+// WithRewriteHook registers fn to be called every time Wrap sanitizes
+// a path before it reaches the wrapped filesystem.
+func WithRewriteHook(fn RewriteFunc) Option {
+	return func(w *Fs) {
+		w.onRewrite = fn
+	}
+}
+
+// Fs wraps an inner afero.Fs so every Create, Mkdir, MkdirAll,
+// OpenFile(..., os.O_CREATE, ...) and Rename call routes its target
+// path through a svach.Svach before it reaches inner. Reads (Open,
+// Stat, Remove, ...) try the raw path first and fall back to its
+// sanitized form, so entries that predate wrapping stay reachable.
+type Fs struct {
+	inner     afero.Fs
+	s         *svach.Svach
+	onRewrite RewriteFunc
+
+	mu       sync.Mutex
+	sessions map[string]*svach.Session
+}
+
+// Wrap returns an afero.Fs backed by inner, with every write path
+// sanitized through s. A Session keyed by sanitized directory keeps
+// names that collide within one mounted tree unique, per s.NewSession.
+func Wrap(inner afero.Fs, s *svach.Svach, opts ...Option) afero.Fs {
+	w := &Fs{
+		inner:    inner,
+		s:        s,
+		sessions: make(map[string]*svach.Session),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// sessionFor returns the Session tracking names already written into
+// the sanitized parent directory dir, creating one the first time dir
+// is seen.
+func (w *Fs) sessionFor(dir string) *svach.Session {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sess, ok := w.sessions[dir]
+	if !ok {
+		sess = w.s.NewSession()
+		w.sessions[dir] = sess
+	}
+	return sess
+}
+
+// rewrite sanitizes name one path component at a time, using
+// svach.PathComponents for the split so it stays consistent with
+// Svach.Path/PathClean, and deduping each component against its
+// sanitized parent's Session. Doing this at every level, not just the
+// leaf, is what stops two different directory names that sanitize
+// alike (e.g. "Café<>" and "Café??") from merging into the same
+// physical directory: the second one's "Café" collides in the root
+// Session and gets suffixed to "Café-2" before its own children are
+// ever sanitized.
+func (w *Fs) rewrite(name string) string {
+	drive, abs, comps := svach.PathComponents(name)
+
+	cur := ""
+	if abs {
+		cur = string(filepath.Separator)
+	}
+
+	for _, c := range comps {
+		if c == "." || c == ".." {
+			cur = joinLiteral(cur, c)
+			continue
+		}
+		cur = joinLiteral(cur, w.sessionFor(cur).UniqueName(c))
+	}
+	cur = drive + cur
+
+	if orig := svach.JoinPath(drive, abs, comps); cur != orig && w.onRewrite != nil {
+		w.onRewrite(name, cur)
+	}
+
+	return cur
+}
+
+// joinLiteral appends comp to base with filepath.Separator, without
+// the lexical cleaning filepath.Join would do - so navigational
+// components like ".." stay literal instead of collapsing the path.
+func joinLiteral(base, comp string) string {
+	sep := string(filepath.Separator)
+	if base == "" || strings.HasSuffix(base, sep) {
+		return base + comp
+	}
+	return base + sep + comp
+}
+
+// resolve returns name unchanged if it already exists in inner,
+// otherwise its sanitized form, so reads of pre-existing unsanitized
+// entries keep working.
+func (w *Fs) resolve(name string) string {
+	if _, err := w.inner.Stat(name); err == nil {
+		return name
+	}
+	return w.s.Path(name)
+}
+
+// Create implements afero.Fs.
+func (w *Fs) Create(name string) (afero.File, error) {
+	return w.inner.Create(w.rewrite(name))
+}
+
+// Mkdir implements afero.Fs.
+func (w *Fs) Mkdir(name string, perm os.FileMode) error {
+	return w.inner.Mkdir(w.rewrite(name), perm)
+}
+
+// MkdirAll implements afero.Fs.
+func (w *Fs) MkdirAll(path string, perm os.FileMode) error {
+	return w.inner.MkdirAll(w.rewrite(path), perm)
+}
+
+// Open implements afero.Fs.
+func (w *Fs) Open(name string) (afero.File, error) {
+	return w.inner.Open(w.resolve(name))
+}
+
+// OpenFile implements afero.Fs.
+func (w *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&os.O_CREATE != 0 {
+		return w.inner.OpenFile(w.rewrite(name), flag, perm)
+	}
+	return w.inner.OpenFile(w.resolve(name), flag, perm)
+}
+
+// Remove implements afero.Fs.
+func (w *Fs) Remove(name string) error {
+	return w.inner.Remove(w.resolve(name))
+}
+
+// RemoveAll implements afero.Fs.
+func (w *Fs) RemoveAll(path string) error {
+	return w.inner.RemoveAll(w.resolve(path))
+}
+
+// Rename implements afero.Fs.
+func (w *Fs) Rename(oldname, newname string) error {
+	return w.inner.Rename(w.resolve(oldname), w.rewrite(newname))
+}
+
+// Stat implements afero.Fs.
+func (w *Fs) Stat(name string) (os.FileInfo, error) {
+	return w.inner.Stat(w.resolve(name))
+}
+
+// Name implements afero.Fs.
+func (w *Fs) Name() string {
+	return "svachfs:" + w.inner.Name()
+}
+
+// Chmod implements afero.Fs.
+func (w *Fs) Chmod(name string, mode os.FileMode) error {
+	return w.inner.Chmod(w.resolve(name), mode)
+}
+
+// Chtimes implements afero.Fs.
+func (w *Fs) Chtimes(name string, atime, mtime time.Time) error {
+	return w.inner.Chtimes(w.resolve(name), atime, mtime)
+}
+
+// Chown implements afero.Fs.
+func (w *Fs) Chown(name string, uid, gid int) error {
+	return w.inner.Chown(w.resolve(name), uid, gid)
+}
+
+// ReadFS adapts an afero.Fs to a read-only io/fs.FS, trying name as
+// given before falling back to its form sanitized through s. Use this
+// to mount svach in front of code that only needs io/fs.FS reads.
+type ReadFS struct {
+	inner afero.Fs
+	s     *svach.Svach
+}
+
+// WrapReadFS returns a read-only io/fs.FS backed by inner, falling
+// back to s.Path(name) when name itself isn't found.
+func WrapReadFS(inner afero.Fs, s *svach.Svach) stdfs.FS {
+	return &ReadFS{inner: inner, s: s}
+}
+
+// Open implements io/fs.FS.
+func (r *ReadFS) Open(name string) (stdfs.File, error) {
+	f, err := r.inner.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	return r.inner.Open(r.s.Path(name))
+}
@@ -0,0 +1,110 @@
+package fs_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/nilsocket/svach"
+	svachfs "github.com/nilsocket/svach/fs"
+)
+
+func TestWrapCreateTwiceOverwritesInsteadOfDuplicating(t *testing.T) {
+	inner := afero.NewMemMapFs()
+	w := svachfs.Wrap(inner, svach.New())
+
+	f, err := w.Create("report.txt")
+	if err != nil {
+		t.Fatalf("first Create: %v", err)
+	}
+	f.WriteString("old")
+	f.Close()
+
+	f, err = w.Create("report.txt")
+	if err != nil {
+		t.Fatalf("second Create: %v", err)
+	}
+	f.WriteString("new")
+	f.Close()
+
+	entries, err := afero.ReadDir(inner, "")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		names := make([]string, len(entries))
+		for i, e := range entries {
+			names[i] = e.Name()
+		}
+		t.Fatalf("got %d entries %v, want exactly 1 (the same file overwritten, not duplicated)", len(entries), names)
+	}
+
+	got, err := afero.ReadFile(inner, "report.txt")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != "new" {
+		t.Errorf("report.txt content = %q, want %q (overwritten, not left stale)", got, "new")
+	}
+}
+
+func TestWrapMkdirAllSameDirTwiceDoesntSuffix(t *testing.T) {
+	inner := afero.NewMemMapFs()
+	w := svachfs.Wrap(inner, svach.New())
+
+	if err := w.MkdirAll("Café/sub", 0o755); err != nil {
+		t.Fatalf("first MkdirAll: %v", err)
+	}
+	if err := w.MkdirAll("Café/sub", 0o755); err != nil {
+		t.Fatalf("second MkdirAll: %v", err)
+	}
+
+	if ok, err := afero.DirExists(inner, "Café/sub"); err != nil || !ok {
+		t.Fatalf("Café/sub should exist, err=%v", err)
+	}
+	if ok, _ := afero.DirExists(inner, "Café-2"); ok {
+		t.Errorf("repeated MkdirAll on the same path created a spurious %q", "Café-2")
+	}
+}
+
+func TestWrapPreservesDotDotLiterally(t *testing.T) {
+	inner := afero.NewMemMapFs()
+
+	var rewritten string
+	w := svachfs.Wrap(inner, svach.New(), svachfs.WithRewriteHook(func(orig, sanitized string) {
+		rewritten = sanitized
+	}))
+
+	f, err := w.Create("a/../b<>.txt")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	f.Close()
+
+	// Like svach.Path, the rewritten path should preserve ".." literally
+	// instead of lexically resolving it away the way filepath.Join would.
+	want := filepath.Join("a") + string(filepath.Separator) + ".." + string(filepath.Separator) + "b.txt"
+	if rewritten != want {
+		t.Errorf("rewritten path = %q, want %q (\"..\" preserved literally)", rewritten, want)
+	}
+}
+
+func TestWrapDistinctCollidingDirsStillGetSuffixed(t *testing.T) {
+	inner := afero.NewMemMapFs()
+	w := svachfs.Wrap(inner, svach.New())
+
+	if err := w.MkdirAll("Café<>/a.txt", 0o755); err != nil {
+		t.Fatalf("first MkdirAll: %v", err)
+	}
+	if err := w.MkdirAll("Café??/b.txt", 0o755); err != nil {
+		t.Fatalf("second MkdirAll: %v", err)
+	}
+
+	if ok, _ := afero.DirExists(inner, "Café"); !ok {
+		t.Errorf("want Café to exist")
+	}
+	if ok, _ := afero.DirExists(inner, "Café-2"); !ok {
+		t.Errorf("want Café-2 to exist for the second, distinct, colliding input")
+	}
+}
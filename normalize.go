@@ -0,0 +1,101 @@
+package svach
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalization holds the settings WithNormalization and ASCIIOnly
+// apply. The zero value runs none of it, so a plain New or
+// NewWithOpts Svach stays byte-for-byte compatible with earlier
+// versions.
+type normalization struct {
+	enabled   bool
+	form      norm.Form
+	asciiOnly bool
+}
+
+// WithNormalization enables a normalization pass that runs before
+// Clean's regex pipeline: form (typically norm.NFC) normalizes the
+// string, then common typographic confusables - curly quotes,
+// en/em dashes, fullwidth ASCII, non-breaking space - are folded to
+// their plain ASCII equivalents. Without this option Clean is
+// unchanged from earlier versions.
+func WithNormalization(form norm.Form) Option {
+	return func(s *Svach) {
+		s.norm.enabled = true
+		s.norm.form = form
+	}
+}
+
+// ASCIIOnly additionally transliterates normalized text to ASCII,
+// decomposing accented letters and dropping their combining marks,
+// then discarding any rune that still isn't ASCII. It has no effect
+// unless combined with WithNormalization.
+func ASCIIOnly() Option {
+	return func(s *Svach) {
+		s.norm.asciiOnly = true
+	}
+}
+
+// confusables folds common typographic lookalikes to their ASCII
+// equivalents.
+var confusables = strings.NewReplacer(
+	"‘", "'", // left single quotation mark
+	"’", "'", // right single quotation mark
+	"“", "\"", // left double quotation mark
+	"”", "\"", // right double quotation mark
+	"–", "-", // en dash
+	"—", "-", // em dash
+	" ", " ", // no-break space
+	"　", " ", // ideographic space
+)
+
+// foldFullwidth maps a fullwidth ASCII variant (U+FF01-U+FF5E) to its
+// halfwidth equivalent, leaving every other rune untouched.
+func foldFullwidth(r rune) rune {
+	if r >= 0xff01 && r <= 0xff5e {
+		return r - 0xfee0
+	}
+	return r
+}
+
+// asciiTransliterate decomposes accented letters and strips their
+// combining marks (e.g. "é" -> "e"), then drops any rune that
+// still isn't ASCII.
+func asciiTransliterate(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, s)
+	if err != nil {
+		out = s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return -1
+		}
+		return r
+	}, out)
+}
+
+// normalize runs cfg's normalization pass over s, or returns s
+// unchanged if cfg is disabled.
+func normalize(s string, cfg normalization) string {
+	if !cfg.enabled {
+		return s
+	}
+
+	s = cfg.form.String(s)
+	s = confusables.Replace(s)
+	s = strings.Map(foldFullwidth, s)
+
+	if cfg.asciiOnly {
+		s = asciiTransliterate(s)
+	}
+
+	return s
+}
@@ -0,0 +1,66 @@
+package svach_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	svach "github.com/nilsocket/svach"
+)
+
+func ExampleSvach_PathClean() {
+	res := svach.DefaultSvach.PathClean(`/home/../user/My   Docs/report??.txt`)
+	fmt.Println(res)
+	// Output: /home/../user/My Docs/report.txt
+}
+
+func TestPathPreservesDriveLetter(t *testing.T) {
+	got := svach.Path(`C:\Users\Bob\My<File>.txt`)
+	want := "C:" + filepath.Join(string(filepath.Separator), "Users", "Bob", "MyFile.txt")
+	if got != want {
+		t.Errorf("Path(%q) = %q, want %q", `C:\Users\Bob\My<File>.txt`, got, want)
+	}
+}
+
+func TestPathComponentsAndJoinPathRoundTrip(t *testing.T) {
+	cases := []struct {
+		path  string
+		drive string
+		abs   bool
+		comps []string
+	}{
+		{`/home/user/report.txt`, "", true, []string{"home", "user", "report.txt"}},
+		{`relative/sub/dir`, "", false, []string{"relative", "sub", "dir"}},
+		{`C:\Users\Bob`, "C:", true, []string{"Users", "Bob"}},
+		{`..`, "", false, []string{".."}},
+	}
+
+	for _, c := range cases {
+		drive, abs, comps := svach.PathComponents(c.path)
+		if drive != c.drive || abs != c.abs || !equalStrings(comps, c.comps) {
+			t.Errorf("PathComponents(%q) = (%q, %v, %v), want (%q, %v, %v)",
+				c.path, drive, abs, comps, c.drive, c.abs, c.comps)
+		}
+
+		want := c.drive + strings.Join(c.comps, string(filepath.Separator))
+		if c.abs {
+			want = c.drive + string(filepath.Separator) + strings.Join(c.comps, string(filepath.Separator))
+		}
+		if got := svach.JoinPath(drive, abs, comps); got != want {
+			t.Errorf("JoinPath(%q, %v, %v) = %q, want %q", drive, abs, comps, got, want)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
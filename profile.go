@@ -0,0 +1,175 @@
+package svach
+
+import (
+	"regexp"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Profile selects the platform-specific rules a Svach enforces: which
+// characters are invalid, which names are reserved, and whether
+// trailing dots/spaces get trimmed.
+type Profile int
+
+const (
+	// ProfilePortable is the intersection of every profile below, so
+	// a name it accepts is valid on every supported platform. It is
+	// the profile used by New and NewWithOpts.
+	ProfilePortable Profile = iota
+
+	// ProfileWindows keeps the historical, pre-Profile behavior:
+	// `<>:"/\|?*` are invalid, `CON`/`PRN`/`AUX`/`NUL`/`COM#`/`LPT#`
+	// are reserved, and trailing dots/spaces are trimmed.
+	ProfileWindows
+
+	// ProfilePOSIX only forbids NUL and `/`.
+	ProfilePOSIX
+
+	// ProfileDarwin forbids NUL, `/` and `:`.
+	ProfileDarwin
+
+	// ProfilePlan9 forbids control characters and `/`.
+	ProfilePlan9
+)
+
+// profileRules holds the regexes and reserved-name table a profile
+// enforces. A nil rightSDExp or leftdotExpr means that profile's names
+// don't need that trimming step. A false preNormalize means the
+// profile doesn't mandate a normalization form; it, not preNormForm,
+// gates the step, since Form's zero value is the valid form NFC.
+type profileRules struct {
+	cntrlExp        *regexp.Regexp
+	invCharExp      *regexp.Regexp
+	rightSDExp      *regexp.Regexp
+	leftdotExpr     *regexp.Regexp
+	invalidNamesMap map[int][]string
+	maxLen          int
+	preNormalize    bool
+	preNormForm     norm.Form
+}
+
+// navigationalNamesMap rejects only the names that every platform
+// treats as navigational, not as real files.
+var navigationalNamesMap = map[int][]string{
+	1: {"."},
+	2: {".."},
+}
+
+// windowsNamesMap is the historical reserved-name table.
+//
+// https://docs.microsoft.com/en-us/windows/win32/fileio/naming-a-file?redirectedfrom=MSDN#naming-conventions
+var windowsNamesMap = map[int][]string{
+	1: {
+		".",
+	},
+
+	2: {
+		"..",
+	},
+
+	3: {
+		"con", "prn", "aux", "nul",
+	},
+
+	4: {
+		"com1", "com2", "com3", "com4",
+		"com5", "com6", "com7", "com8",
+		"com9", "lpt1", "lpt2", "lpt3",
+		"lpt4", "lpt5", "lpt6", "lpt7",
+		"lpt8", "lpt9",
+	},
+}
+
+var windowsRules = profileRules{
+	cntrlExp:        regexp.MustCompile("[[:cntrl:]]"),
+	invCharExp:      regexp.MustCompile(`[<>:"/\\|\?\*]+`),
+	rightSDExp:      regexp.MustCompile("(?s:[[:space:]]|\\.)+$"),
+	leftdotExpr:     regexp.MustCompile("^\\.+"),
+	invalidNamesMap: windowsNamesMap,
+	maxLen:          iMaxLen,
+}
+
+var posixRules = profileRules{
+	cntrlExp:        regexp.MustCompile("\x00"),
+	invCharExp:      regexp.MustCompile("/+"),
+	invalidNamesMap: navigationalNamesMap,
+	maxLen:          iMaxLen,
+}
+
+// darwinRules NFD-normalizes unconditionally: HFS+/APFS themselves
+// store file names in NFD, so two inputs that print identically but
+// differ in composed vs. decomposed form (e.g. precomposed "é" vs.
+// "e" + combining acute) must sanitize to the same bytes, not two
+// visually-identical but distinct names.
+var darwinRules = profileRules{
+	cntrlExp:        regexp.MustCompile("\x00"),
+	invCharExp:      regexp.MustCompile("[:/]+"),
+	invalidNamesMap: navigationalNamesMap,
+	maxLen:          iMaxLen,
+	preNormalize:    true,
+	preNormForm:     norm.NFD,
+}
+
+var plan9Rules = profileRules{
+	cntrlExp:        regexp.MustCompile("[[:cntrl:]]"),
+	invCharExp:      regexp.MustCompile("/+"),
+	invalidNamesMap: navigationalNamesMap,
+	maxLen:          iMaxLen,
+}
+
+// profiles maps each Profile to its rules. ProfilePortable reuses
+// ProfileWindows's rules, as it is the strictest and therefore the
+// intersection of the rest.
+var profiles = map[Profile]profileRules{
+	ProfilePortable: windowsRules,
+	ProfileWindows:  windowsRules,
+	ProfilePOSIX:    posixRules,
+	ProfileDarwin:   darwinRules,
+	ProfilePlan9:    plan9Rules,
+}
+
+// Option configures a Svach returned by WithProfile.
+type Option func(*Svach)
+
+// WithReplaceStr overrides the replacement string used for invalid
+// characters and repeated separators, default "".
+func WithReplaceStr(replaceStr string) Option {
+	return func(s *Svach) {
+		s.replaceStr = replaceStr
+	}
+}
+
+// WithMaxLen overrides the maximum length of sanitized output.
+func WithMaxLen(maxLen int) Option {
+	return func(s *Svach) {
+		s.maxLen = maxLen
+	}
+}
+
+// WithProfile returns a Svach enforcing p's rules, with opts applied
+// on top of its defaults.
+//
+// Unlike NewWithOpts, invalid opts aren't rejected with an error;
+// opts are applied as given, same as functional options elsewhere.
+func WithProfile(p Profile, opts ...Option) *Svach {
+	rules := profiles[p]
+
+	s := &Svach{
+		replaceStr: "",
+		maxLen:     rules.maxLen,
+		profile:    p,
+		rules:      rules,
+		fallback:   MD5Fallback,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Profile returns the profile s was constructed with.
+func (s *Svach) Profile() Profile {
+	return s.profile
+}
@@ -0,0 +1,104 @@
+package svach_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/nilsocket/svach"
+)
+
+// md5Hex matches the hex md5 fallback Name/Clean return for a name
+// that doesn't survive sanitization.
+var md5Hex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+func TestProfiles(t *testing.T) {
+	cases := []struct {
+		desc    string
+		profile svach.Profile
+		input   string
+		want    string
+	}{
+		{
+			"Windows strips <>:\"/\\|?*",
+			svach.ProfileWindows,
+			`a<b>c:d"e/f\g|h?i*j`,
+			"abcdefghij",
+		},
+		{
+			"Windows trims trailing dots and spaces",
+			svach.ProfileWindows,
+			"report.   ",
+			"report",
+		},
+		{
+			"Portable matches Windows",
+			svach.ProfilePortable,
+			`a<b>c:d"e/f\g|h?i*j`,
+			"abcdefghij",
+		},
+		{
+			"POSIX only strips /",
+			svach.ProfilePOSIX,
+			`a<b>c:d"e/f\g|h?i*j`,
+			`a<b>c:d"ef\g|h?i*j`,
+		},
+		{
+			"POSIX keeps trailing dots and spaces",
+			svach.ProfilePOSIX,
+			"report.   ",
+			"report.   ",
+		},
+		{
+			"Darwin strips : and /, keeps the rest",
+			svach.ProfileDarwin,
+			`a<b>c:d"e/f\g|h?i*j`,
+			`a<b>cd"ef\g|h?i*j`,
+		},
+		{
+			"Plan9 strips control chars and /, keeps :",
+			svach.ProfilePlan9,
+			"a\x01b/c:d",
+			"abc:d",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			s := svach.WithProfile(c.profile)
+			if got := s.Name(c.input); got != c.want {
+				t.Errorf("Name(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestProfilesReservedNamesFallBack(t *testing.T) {
+	cases := []struct {
+		desc    string
+		profile svach.Profile
+		input   string
+	}{
+		{"Windows rejects CON", svach.ProfileWindows, "CON"},
+		{"Windows rejects LPT1", svach.ProfileWindows, "LPT1"},
+		{"POSIX rejects .. as navigational, not a real name", svach.ProfilePOSIX, ".."},
+		{"Darwin rejects . as navigational, not a real name", svach.ProfileDarwin, "."},
+		{"Plan9 rejects .. as navigational, not a real name", svach.ProfilePlan9, ".."},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			s := svach.WithProfile(c.profile)
+			got := s.Name(c.input)
+			if !md5Hex.MatchString(got) {
+				t.Errorf("Name(%q) = %q, want an md5 fallback", c.input, got)
+			}
+		})
+	}
+}
+
+func TestProfileGetter(t *testing.T) {
+	s := svach.WithProfile(svach.ProfileDarwin)
+	if got := s.Profile(); got != svach.ProfileDarwin {
+		t.Errorf("Profile() = %v, want ProfileDarwin", got)
+	}
+}
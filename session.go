@@ -0,0 +1,85 @@
+package svach
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxSuffixAttempts bounds the counter-suffix search in unique before
+// falling back to a hash suffix.
+const maxSuffixAttempts = 1000
+
+// Session remembers every name it has emitted so repeated collisions
+// within a run get a unique, deterministic suffix instead of silently
+// sanitizing two different inputs down to the same name. Resolving the
+// same original again (e.g. overwriting a file, or MkdirAll on a
+// directory that already exists) returns its previously assigned name
+// instead of a fresh suffix, so ordinary repeat writes aren't mistaken
+// for collisions.
+type Session struct {
+	s      *Svach
+	mu     sync.Mutex
+	seen   map[string]bool
+	byOrig map[string]string
+}
+
+// NewSession returns a Session that dedupes names produced by s.
+func (s *Svach) NewSession() *Session {
+	return &Session{s: s, seen: make(map[string]bool), byOrig: make(map[string]string)}
+}
+
+// UniqueName is Name, deduplicated against every name sess has already
+// emitted.
+func (sess *Session) UniqueName(orig string) string {
+	return sess.unique("name\x00"+orig, orig, sess.s.Name)
+}
+
+// UniqueClean is Clean, deduplicated against every name sess has
+// already emitted.
+func (sess *Session) UniqueClean(orig string) string {
+	return sess.unique("clean\x00"+orig, orig, sess.s.Clean)
+}
+
+func (sess *Session) unique(cacheKey, orig string, sanitize func(string) string) string {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if name, ok := sess.byOrig[cacheKey]; ok {
+		return name
+	}
+
+	name := sanitize(orig)
+
+	if !sess.seen[name] {
+		sess.seen[name] = true
+		sess.byOrig[cacheKey] = name
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if base == "" {
+		// name is a dotfile like ".bashrc" - filepath.Ext treats the
+		// whole name as the extension, which would otherwise empty
+		// the base and produce a suffix-only candidate like "-2.bashrc".
+		base, ext = name, ""
+	}
+
+	for n := 2; n <= maxSuffixAttempts; n++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, n, ext)
+		if !sess.seen[candidate] {
+			sess.seen[candidate] = true
+			sess.byOrig[cacheKey] = candidate
+			return candidate
+		}
+	}
+
+	sum := sha256.Sum256([]byte(orig))
+	candidate := fmt.Sprintf("%s-%x%s", base, sum[:4], ext)
+	sess.seen[candidate] = true
+	sess.byOrig[cacheKey] = candidate
+	return candidate
+}
@@ -0,0 +1,133 @@
+package svach_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nilsocket/svach"
+)
+
+func TestSessionUniqueNameDedupes(t *testing.T) {
+	s := svach.New()
+	sess := s.NewSession()
+
+	// Three different inputs that all sanitize to the same name - the
+	// "Café<>" / "Café??" collision the fs adapter has to handle.
+	first := sess.UniqueName("café")
+	second := sess.UniqueName("café<>")
+	third := sess.UniqueName("café??")
+
+	if first != "café" {
+		t.Fatalf("UniqueName(%q) = %q, want %q", "café", first, "café")
+	}
+	if second != "café-2" {
+		t.Errorf("UniqueName(%q) = %q, want %q", "café<>", second, "café-2")
+	}
+	if third != "café-3" {
+		t.Errorf("UniqueName(%q) = %q, want %q", "café??", third, "café-3")
+	}
+}
+
+func TestSessionUniqueNamePreservesExtension(t *testing.T) {
+	s := svach.New()
+	sess := s.NewSession()
+
+	first := sess.UniqueName("report.pdf")
+	second := sess.UniqueName("report<>.pdf")
+
+	if first != "report.pdf" {
+		t.Fatalf("UniqueName(%q) = %q, want %q", "report.pdf", first, "report.pdf")
+	}
+	if second != "report-2.pdf" {
+		t.Errorf("UniqueName(%q) = %q, want %q", "report<>.pdf", second, "report-2.pdf")
+	}
+}
+
+func TestSessionUniqueNameDotfile(t *testing.T) {
+	s := svach.New()
+	sess := s.NewSession()
+
+	first := sess.UniqueName(".bashrc")
+	second := sess.UniqueName(".bashrc<>")
+
+	if first != ".bashrc" {
+		t.Fatalf("UniqueName(%q) = %q, want %q", ".bashrc", first, ".bashrc")
+	}
+	if second != ".bashrc-2" {
+		t.Errorf("UniqueName(%q) = %q, want %q", ".bashrc<>", second, ".bashrc-2")
+	}
+}
+
+func TestSessionUniqueCleanDedupes(t *testing.T) {
+	s := svach.New()
+	sess := s.NewSession()
+
+	first := sess.UniqueClean("Report  Final")
+	second := sess.UniqueClean("Report   Final")
+
+	if first != "Report Final" {
+		t.Fatalf("UniqueClean(%q) = %q, want %q", "Report  Final", first, "Report Final")
+	}
+	if second != "Report Final-2" {
+		t.Errorf("UniqueClean(%q) = %q, want %q", "Report   Final", second, "Report Final-2")
+	}
+}
+
+func TestSessionsAreIndependent(t *testing.T) {
+	s := svach.New()
+	a := s.NewSession()
+	b := s.NewSession()
+
+	if got := a.UniqueName("dup"); got != "dup" {
+		t.Fatalf("a.UniqueName(%q) = %q, want %q", "dup", got, "dup")
+	}
+	if got := b.UniqueName("dup"); got != "dup" {
+		t.Errorf("b.UniqueName(%q) = %q, want %q (independent session)", "dup", got, "dup")
+	}
+}
+
+// distinctDupOrigs returns n distinct originals that all sanitize to
+// "dup" (trailing "?" is invalid and gets stripped), so each call is a
+// genuinely new collision rather than a repeat of the same original.
+func distinctDupOrigs(n int) []string {
+	origs := make([]string, n)
+	for i := range origs {
+		origs[i] = "dup" + strings.Repeat("?", i+1)
+	}
+	return origs
+}
+
+// TestSessionFallsBackToHashSuffix exhausts the counter-suffix range so
+// unique falls back to a short sha256 suffix instead of looping forever.
+func TestSessionFallsBackToHashSuffix(t *testing.T) {
+	s := svach.New()
+	sess := s.NewSession()
+
+	// "dup" claims the bare name, then the next 999 distinct collisions
+	// claim "dup-2".."dup-1000", filling every counter-suffix slot
+	// unique tries before falling back to a hash.
+	sess.UniqueName("dup")
+	origs := distinctDupOrigs(999)
+	for _, orig := range origs {
+		sess.UniqueName(orig)
+	}
+
+	overflow := "dup" + strings.Repeat("?", 1000)
+	got := sess.UniqueName(overflow)
+	if !regexp.MustCompile(`^dup-[0-9a-f]{8}$`).MatchString(got) {
+		t.Errorf("UniqueName(%q) after exhausting counters = %q, want a %q-prefixed hash suffix", overflow, got, "dup-")
+	}
+
+	// Deterministic: the same sequence of distinct originals hashes
+	// the overflowing one to the same suffix in an independent Session.
+	sess2 := s.NewSession()
+	sess2.UniqueName("dup")
+	for _, orig := range origs {
+		sess2.UniqueName(orig)
+	}
+	got2 := sess2.UniqueName(overflow)
+	if got2 != got {
+		t.Errorf("hash suffix isn't deterministic: got %q and %q for the same orig", got, got2)
+	}
+}
@@ -9,10 +9,9 @@
 package svach
 
 import (
-	"crypto/md5"
 	"errors"
-	"fmt"
 	"html"
+	"path/filepath"
 	"regexp"
 	"strings"
 )
@@ -21,6 +20,10 @@ import (
 type Svach struct {
 	replaceStr string
 	maxLen     int
+	profile    Profile
+	rules      profileRules
+	fallback   FallbackFunc
+	norm       normalization
 }
 
 var (
@@ -37,22 +40,39 @@ var iMaxLen = 240
 
 // New returns a Svach object
 func New() *Svach {
-	return &Svach{"", iMaxLen}
+	return &Svach{
+		replaceStr: "",
+		maxLen:     iMaxLen,
+		profile:    ProfilePortable,
+		rules:      profiles[ProfilePortable],
+		fallback:   MD5Fallback,
+	}
 }
 
-var (
-	cntrlExp = regexp.MustCompile("[[:cntrl:]]") // control
+// std is the package-level Svach used by Path, Clean, Name and DefaultSvach.
+var std = New()
 
-	// invalid characters - windows
-	// <, >, :, ", /, \, |, ?, *
-	invCharExp = regexp.MustCompile(`[<>:"/\\|\?\*]+`)
+// DefaultSvach is the package-level Svach used by Clean, Name and Path.
+var DefaultSvach = std
 
-	// trim right spaces and dot
-	rightSDExp = regexp.MustCompile("(?s:[[:space:]]|\\.)+$")
+// WithOpts returns a Svach object, with opts set, same as NewWithOpts.
+func WithOpts(replaceStr string, maxLen int) (*Svach, error) {
+	return NewWithOpts(replaceStr, maxLen)
+}
 
-	// trim left dot's
-	leftdotExpr = regexp.MustCompile("^\\.+")
-)
+// Clean svachs `fileName` using DefaultSvach.
+//
+// See (*Svach).Clean.
+func Clean(fileName string) string {
+	return std.Clean(fileName)
+}
+
+// Name svachs `fileName` using DefaultSvach.
+//
+// See (*Svach).Name.
+func Name(fileName string) string {
+	return std.Name(fileName)
+}
 
 // NewWithOpts returns Svach object, with opts set
 // and returns error, if conditions aren't met.
@@ -63,9 +83,9 @@ var (
 //
 // - maxlen can't be greater than 255
 func NewWithOpts(replaceStr string, maxLen int) (*Svach, error) {
-	s := &Svach{"", iMaxLen}
+	s := New()
 
-	if err := validOptStr(replaceStr); err != nil {
+	if err := validOptStr(replaceStr, s.rules); err != nil {
 		return s, err
 	}
 
@@ -73,15 +93,17 @@ func NewWithOpts(replaceStr string, maxLen int) (*Svach, error) {
 		return s, ErrLen
 	}
 
-	return &Svach{replaceStr, maxLen}, nil
+	s.replaceStr = replaceStr
+	s.maxLen = maxLen
+	return s, nil
 }
 
-func validOptStr(s string) error {
-	if cntrlExp.MatchString(s) {
+func validOptStr(s string, rules profileRules) error {
+	if rules.cntrlExp.MatchString(s) {
 		return ErrCntrl
 	}
 
-	if invCharExp.MatchString(s) || strings.Contains(s, ".") {
+	if rules.invCharExp.MatchString(s) || strings.Contains(s, ".") {
 		return ErrInval
 	}
 	return nil
@@ -89,19 +111,26 @@ func validOptStr(s string) error {
 
 // Name svachs `fileName`
 func (s *Svach) Name(fileName string) string {
-	return name(fileName, s.replaceStr, s.maxLen)
+	return name(fileName, s.replaceStr, s.maxLen, s.rules, s.fallback)
 }
 
-func name(fileName, replaceStr string, maxLen int) string {
+func name(fileName, replaceStr string, maxLen int, rules profileRules, fallback FallbackFunc) string {
 
 	intrStr := strings.ToValidUTF8(fileName, replaceStr) // intermediate string
 	intrStr = html.UnescapeString(intrStr)
-	intrStr = cntrlExp.ReplaceAllString(intrStr, replaceStr)
-	intrStr = invCharExp.ReplaceAllString(intrStr, replaceStr)
-	intrStr = rightSDExp.ReplaceAllString(intrStr, replaceStr)
-	intrStr = leftdotExpr.ReplaceAllString(intrStr, ".")
+	if rules.preNormalize {
+		intrStr = rules.preNormForm.String(intrStr)
+	}
+	intrStr = rules.cntrlExp.ReplaceAllString(intrStr, replaceStr)
+	intrStr = rules.invCharExp.ReplaceAllString(intrStr, replaceStr)
+	if rules.rightSDExp != nil {
+		intrStr = rules.rightSDExp.ReplaceAllString(intrStr, replaceStr)
+	}
+	if rules.leftdotExpr != nil {
+		intrStr = rules.leftdotExpr.ReplaceAllString(intrStr, ".")
+	}
 
-	return validName(fileName, intrStr, replaceStr, maxLen)
+	return validName(fileName, intrStr, replaceStr, maxLen, rules, fallback)
 }
 
 var (
@@ -130,21 +159,25 @@ var (
 // Remove invisible and control characters, repeated separators.
 // Replace different kinds of spaces with normal space.
 func (s *Svach) Clean(fileName string) string {
-	return clean(fileName, s.replaceStr, s.maxLen)
+	return clean(fileName, s.replaceStr, s.maxLen, s.rules, s.fallback, s.norm)
 }
 
-func clean(fileName, replaceStr string, maxLen int) string {
+func clean(fileName, replaceStr string, maxLen int, rules profileRules, fallback FallbackFunc, normCfg normalization) string {
 
 	intrStr := strings.ToValidUTF8(fileName, replaceStr)
 
 	if intrStr != "" {
 		intrStr = html.UnescapeString(intrStr)
+		if rules.preNormalize {
+			intrStr = rules.preNormForm.String(intrStr)
+		}
+		intrStr = normalize(intrStr, normCfg)
 
 		// invisible characters
 		intrStr = unicodeControl.ReplaceAllString(intrStr, replaceStr)
 		intrStr = unicodeSpace.ReplaceAllString(intrStr, " ")
 
-		intrStr = invCharExp.ReplaceAllString(intrStr, replaceStr)
+		intrStr = rules.invCharExp.ReplaceAllString(intrStr, replaceStr)
 
 		var replaceExpr *regexp.Regexp
 		if replaceStr != "" {
@@ -162,8 +195,12 @@ func clean(fileName, replaceStr string, maxLen int) string {
 				intrStr = replaceExpr.ReplaceAllString(intrStr, replaceStr)
 			}
 
-			intrStr = rightSDExp.ReplaceAllString(intrStr, replaceStr)
-			intrStr = leftdotExpr.ReplaceAllString(intrStr, ".")
+			if rules.rightSDExp != nil {
+				intrStr = rules.rightSDExp.ReplaceAllString(intrStr, replaceStr)
+			}
+			if rules.leftdotExpr != nil {
+				intrStr = rules.leftdotExpr.ReplaceAllString(intrStr, ".")
+			}
 
 			if startStr == intrStr {
 				break
@@ -173,13 +210,92 @@ func clean(fileName, replaceStr string, maxLen int) string {
 
 	}
 
-	return validName(fileName, intrStr, replaceStr, maxLen)
+	return validName(fileName, intrStr, replaceStr, maxLen, rules, fallback)
+}
+
+// Path svachs a multi-segment path one component at a time, running
+// each component through Name.
+//
+// See PathComponents for how `p` is split and a Windows drive letter
+// preserved, and JoinPath for how the sanitized components are
+// rejoined. Navigational components (`.`, `..`) are preserved as-is,
+// rather than being rewritten as md5.
+func (s *Svach) Path(p string) string {
+	return s.path(p, s.Name)
+}
+
+// PathClean is Path, but runs each component through Clean instead of
+// Name.
+func (s *Svach) PathClean(p string) string {
+	return s.path(p, s.Clean)
+}
+
+func (s *Svach) path(p string, sanitize func(string) string) string {
+	if p == "" {
+		return p
+	}
+
+	drive, abs, comps := PathComponents(p)
+
+	for i, c := range comps {
+		if c != "." && c != ".." {
+			comps[i] = sanitize(c)
+		}
+	}
+
+	return JoinPath(drive, abs, comps)
+}
+
+// Path svachs `p` using a default Svach.
+//
+// See (*Svach).Path.
+func Path(p string) string {
+	return std.Path(p)
+}
+
+// PathComponents splits p into its Windows drive letter prefix (e.g.
+// "C:" in `C:\Users\Bob`, empty if there isn't one), whether it's
+// absolute, and its non-empty path components split on both `/` and
+// `\`, so OS-native and slash-delimited input produce the same
+// components. It's exported so callers that need custom per-component
+// handling (like svach/fs's Session-aware rewriter) don't have to
+// reimplement path splitting themselves; pair it with JoinPath.
+func PathComponents(p string) (drive string, abs bool, comps []string) {
+	rest := p
+	if len(rest) >= 2 && isDriveLetter(rest[0]) && rest[1] == ':' {
+		drive, rest = rest[:2], rest[2:]
+	}
+
+	abs = rest != "" && (rest[0] == '/' || rest[0] == '\\')
+
+	comps = strings.FieldsFunc(rest, func(r rune) bool {
+		return r == '/' || r == '\\'
+	})
+
+	return drive, abs, comps
 }
 
-func validName(fileName, intrStr, replaceStr string, maxLen int) string {
+// JoinPath rejoins drive, abs and comps - as produced by
+// PathComponents, or filtered/sanitized from them - into a path
+// string using filepath.Separator. Unlike filepath.Join, it does no
+// lexical cleaning: navigational components like ".." are preserved
+// exactly as given.
+func JoinPath(drive string, abs bool, comps []string) string {
+	joined := strings.Join(comps, string(filepath.Separator))
+	if abs {
+		joined = string(filepath.Separator) + joined
+	}
+	return drive + joined
+}
+
+func isDriveLetter(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+func validName(fileName, intrStr, replaceStr string, maxLen int, rules profileRules, fallback FallbackFunc) string {
 
 	if intrStr != replaceStr && intrStr != "" {
-		if valid(intrStr) {
+		if valid(intrStr, rules.invalidNamesMap) {
 			if len(intrStr) > maxLen {
 				return strings.ToValidUTF8(intrStr[:maxLen], "")
 			}
@@ -187,10 +303,10 @@ func validName(fileName, intrStr, replaceStr string, maxLen int) string {
 		}
 	}
 
-	return fmt.Sprintf("%x", md5.Sum([]byte(fileName)))
+	return fallback(fileName)
 }
 
-func valid(name string) bool {
+func valid(name string, invalidNamesMap map[int][]string) bool {
 	if name == "" {
 		return false
 	}
@@ -206,30 +322,6 @@ func valid(name string) bool {
 	return true
 }
 
-// https://docs.microsoft.com/en-us/windows/win32/fileio/naming-a-file?redirectedfrom=MSDN#naming-conventions
-// invalidNamesMap with len of value as key
-var invalidNamesMap = map[int][]string{
-	1: {
-		".",
-	},
-
-	2: {
-		"..",
-	},
-
-	3: {
-		"con", "prn", "aux", "nul",
-	},
-
-	4: {
-		"com1", "com2", "com3", "com4",
-		"com5", "com6", "com7", "com8",
-		"com9", "lpt1", "lpt2", "lpt3",
-		"lpt4", "lpt5", "lpt6", "lpt7",
-		"lpt8", "lpt9",
-	},
-}
-
 func repeatedCharsExp(vals []string) *regexp.Regexp {
 	var s strings.Builder
 	for i, val := range vals {
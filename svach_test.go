@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/nilsocket/svach"
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
@@ -169,3 +170,18 @@ func ExampleName() {
 	fmt.Println(res)
 	// Output: 3e4bde3cb1e4c9cfa2db74bbc536d5e2
 }
+
+func ExamplePath() {
+	res := svach.Path(`/home/../user/My<Docs>/report?.txt`)
+	fmt.Println(res)
+	// Output: /home/../user/MyDocs/report.txt
+}
+
+func ExampleWithNormalization() {
+	// Curly quotes fold to the ASCII quote, which ProfilePortable then
+	// strips as an invalid filename character.
+	s := svach.WithProfile(svach.ProfilePortable, svach.WithNormalization(norm.NFC))
+	res := s.Clean("“Héllo” World")
+	fmt.Println(res)
+	// Output: Héllo World
+}